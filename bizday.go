@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// bizDayOptions bundles the holiday-lookup configuration threaded through
+// BusinessDays and CountBetween.
+type bizDayOptions struct {
+	sources  []HolidaySource  // failover chain, ordered by -source
+	strict   bool             // cross-check every result against verify
+	verify   HolidaySource    // jp-holiday.net, used only when strict
+	calendar *companyCalendar // -calendar overlay, nil if not configured
+}
+
+// isBusinessDayAt reports whether date is a business day: not a closed
+// weekday, not a national holiday, then the company calendar overlay (if
+// any) applied on top.
+func isBusinessDayAt(date time.Time, holidays map[string]bool, opts bizDayOptions) bool {
+	isBizDay := !isWeekdayClosed(date, opts.calendar) && !holidays[dateKey(date)]
+	return applyCalendarOverlay(date, isBizDay, opts.calendar)
+}
+
+// isValidSource reports whether name is a recognized -source value.
+func isValidSource(name string) bool {
+	for _, s := range sourceOrder {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidDirection reports whether name is a recognized -direction value.
+func isValidDirection(name string) bool {
+	return name == "prev" || name == "next"
+}
+
+// BusinessDays returns the Nth business day before (direction "prev") or
+// after (direction "next") from. Holiday lookups for the whole scan window
+// are batched in one pass rather than one call per day.
+func BusinessDays(from time.Time, n int, direction string, opts bizDayOptions) (time.Time, error) {
+	if n < 1 {
+		return time.Time{}, fmt.Errorf("n には1以上を指定してください")
+	}
+	if !isValidDirection(direction) {
+		return time.Time{}, fmt.Errorf("direction にはprevまたはnextを指定してください: %s", direction)
+	}
+
+	step := -1
+	if direction == "next" {
+		step = 1
+	}
+
+	// A generous window: 30 calendar days per business day needed is more
+	// than enough slack for consecutive holidays plus weekends.
+	maxDays := 30 * n
+
+	var lo, hi time.Time
+	if step < 0 {
+		hi = from.AddDate(0, 0, -1)
+		lo = hi.AddDate(0, 0, -(maxDays - 1))
+	} else {
+		lo = from.AddDate(0, 0, 1)
+		hi = lo.AddDate(0, 0, maxDays-1)
+	}
+
+	holidays, err := batchCheckHolidays(lo, hi, opts.sources)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("営業日判定エラー: %w", err)
+	}
+
+	current := from.AddDate(0, 0, step)
+	count := 0
+	for i := 0; i < maxDays; i++ {
+		if isBusinessDayAt(current, holidays, opts) {
+			if opts.strict {
+				verifyIsHoliday, _, err := opts.verify.IsHoliday(current)
+				if err != nil {
+					return time.Time{}, err
+				}
+				if verifyIsHoliday {
+					fmt.Fprintf(os.Stderr, "警告: %s の祝日判定がsourceとAPIで異なるため除外します\n",
+						current.Format("2006-01-02"))
+					current = current.AddDate(0, 0, step)
+					continue
+				}
+			}
+			count++
+			if count == n {
+				return current, nil
+			}
+		}
+		current = current.AddDate(0, 0, step)
+	}
+
+	return time.Time{}, fmt.Errorf("営業日が見つかりませんでした")
+}
+
+// CountBetween reports the number of business days in the inclusive
+// [from, to] range.
+func CountBetween(from, to time.Time, opts bizDayOptions) (int, error) {
+	if to.Before(from) {
+		return 0, fmt.Errorf("count-betweenはfromがto以前である必要があります")
+	}
+
+	holidays, err := batchCheckHolidays(from, to, opts.sources)
+	if err != nil {
+		return 0, fmt.Errorf("営業日判定エラー: %w", err)
+	}
+
+	count := 0
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if !isBusinessDayAt(d, holidays, opts) {
+			continue
+		}
+		if opts.strict {
+			verifyIsHoliday, _, err := opts.verify.IsHoliday(d)
+			if err != nil {
+				return 0, err
+			}
+			if verifyIsHoliday {
+				fmt.Fprintf(os.Stderr, "警告: %s の祝日判定がsourceとAPIで異なるため除外します\n",
+					d.Format("2006-01-02"))
+				continue
+			}
+		}
+		count++
+	}
+	return count, nil
+}
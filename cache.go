@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// cacheFileName is the file holidayCache persists its entries to, inside the
+// configured cache directory.
+const cacheFileName = "holidays.json"
+
+// cacheEntry is one cached jp-holiday.net API response.
+type cacheEntry struct {
+	Holiday   bool      `json:"holiday"`
+	Name      string    `json:"name"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// holidayCache is an on-disk cache of jp-holiday.net responses, keyed by
+// YYYY-MM-DD. Entries for past dates are immutable and never expire; entries
+// for today or the future are only trusted for ttl.
+type holidayCache struct {
+	mu      sync.Mutex
+	path    string
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+// defaultCacheDir returns the default cache directory, following the
+// platform's standard cache location (XDG_CACHE_HOME / ~/.cache on Linux).
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "jp-prev-bizday")
+}
+
+// newHolidayCache loads dir/holidays.json, if present, into memory.
+func newHolidayCache(dir string, ttl time.Duration) *holidayCache {
+	c := &holidayCache{
+		path:    filepath.Join(dir, cacheFileName),
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+	_ = json.Unmarshal(data, &c.entries) // corrupt cache is treated as empty
+
+	return c
+}
+
+// get returns the cached entry for date, if one exists and is still valid.
+func (c *holidayCache) get(date time.Time) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[dateKey(date)]
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	if isPastDate(date) {
+		return entry, true
+	}
+
+	if time.Since(entry.FetchedAt) > c.ttl {
+		return cacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+// set records a fresh API response and persists the cache to disk.
+func (c *holidayCache) set(date time.Time, isHoliday bool, name string) error {
+	c.mu.Lock()
+	c.entries[dateKey(date)] = cacheEntry{
+		Holiday:   isHoliday,
+		Name:      name,
+		FetchedAt: time.Now(),
+	}
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0o644)
+}
+
+// dateKey formats date as the cache's YYYY-MM-DD map key.
+func dateKey(date time.Time) string {
+	return date.Format("2006-01-02")
+}
+
+// isPastDate reports whether date is strictly before today, where "today" is
+// reckoned in JST, matching the default -date used elsewhere in this
+// program. A server running in another timezone (e.g. a UTC container) must
+// not classify a same-day JST lookup as past based on its own local clock.
+func isPastDate(date time.Time) bool {
+	jst, _ := time.LoadLocation("Asia/Tokyo")
+	today := time.Now().In(jst)
+	y1, m1, d1 := date.Date()
+	y2, m2, d2 := today.Date()
+	if y1 != y2 {
+		return y1 < y2
+	}
+	if m1 != m2 {
+		return m1 < m2
+	}
+	return d1 < d2
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// companyCalendar is a -calendar overlay applied on top of the national
+// holiday calendar: extra closures, days that are worked despite normally
+// being off, and which weekdays count as closed at all.
+type companyCalendar struct {
+	ClosedDates    map[string]bool
+	OpenDates      map[string]bool
+	ClosedWeekdays map[time.Weekday]bool
+}
+
+// rawCompanyCalendar is the on-disk shape of a -calendar file, common to
+// both its JSON and YAML encodings.
+type rawCompanyCalendar struct {
+	ClosedDates    []string `json:"closed_dates" yaml:"closed_dates"`
+	OpenDates      []string `json:"open_dates" yaml:"open_dates"`
+	ClosedWeekdays []string `json:"closed_weekdays" yaml:"closed_weekdays"`
+}
+
+// weekdayNames maps the short English names used in -calendar files to
+// time.Weekday.
+var weekdayNames = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+// loadCompanyCalendar reads a -calendar file, chosen as JSON or YAML by its
+// extension (.json vs .yaml/.yml).
+func loadCompanyCalendar(path string) (*companyCalendar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw rawCompanyCalendar
+	switch ext := filepath.Ext(path); ext {
+	case ".json":
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("JSONパースエラー: %w", err)
+		}
+	case ".yaml", ".yml":
+		if err := parseCalendarYAML(data, &raw); err != nil {
+			return nil, fmt.Errorf("YAMLパースエラー: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("未対応のcalendarファイル形式です (.json, .yaml, .ymlのみ対応): %s", path)
+	}
+
+	closedWeekdays := map[time.Weekday]bool{time.Saturday: true, time.Sunday: true}
+	if len(raw.ClosedWeekdays) > 0 {
+		closedWeekdays = make(map[time.Weekday]bool, len(raw.ClosedWeekdays))
+		for _, name := range raw.ClosedWeekdays {
+			wd, ok := weekdayNames[name]
+			if !ok {
+				return nil, fmt.Errorf("不明な曜日です: %s", name)
+			}
+			closedWeekdays[wd] = true
+		}
+	}
+
+	return &companyCalendar{
+		ClosedDates:    toDateSet(raw.ClosedDates),
+		OpenDates:      toDateSet(raw.OpenDates),
+		ClosedWeekdays: closedWeekdays,
+	}, nil
+}
+
+func toDateSet(dates []string) map[string]bool {
+	set := make(map[string]bool, len(dates))
+	for _, d := range dates {
+		set[d] = true
+	}
+	return set
+}
+
+// isWeekdayClosed reports whether date's weekday is closed under cal, or
+// under the default Saturday/Sunday weekend when cal is nil.
+func isWeekdayClosed(date time.Time, cal *companyCalendar) bool {
+	if cal != nil {
+		return cal.ClosedWeekdays[date.Weekday()]
+	}
+	return isWeekend(date)
+}
+
+// applyCalendarOverlay adjusts a national-holiday-only business day verdict
+// to account for the company calendar's extra closures and open days.
+// open_dates takes precedence over closed_dates, since it's the more
+// specific, more recently decided override of the two.
+func applyCalendarOverlay(date time.Time, isBizDay bool, cal *companyCalendar) bool {
+	if cal == nil {
+		return isBizDay
+	}
+	key := dateKey(date)
+	if cal.ClosedDates[key] {
+		isBizDay = false
+	}
+	if cal.OpenDates[key] {
+		isBizDay = true
+	}
+	return isBizDay
+}
+
+// parseCalendarYAML parses the restricted subset of YAML a -calendar file
+// needs: three top-level keys, each a list of strings given either inline
+// ("key: [a, b]") or as a block ("key:" followed by "- item" lines).
+func parseCalendarYAML(data []byte, raw *rawCompanyCalendar) error {
+	var currentField *[]string
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if currentField == nil {
+				return fmt.Errorf("キーのないリスト項目です: %q", line)
+			}
+			*currentField = append(*currentField, strings.TrimSpace(strings.TrimPrefix(trimmed, "-")))
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return fmt.Errorf("不正な行です: %q", line)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "closed_dates":
+			currentField = &raw.ClosedDates
+		case "open_dates":
+			currentField = &raw.OpenDates
+		case "closed_weekdays":
+			currentField = &raw.ClosedWeekdays
+		default:
+			return fmt.Errorf("不明なキーです: %s", key)
+		}
+
+		if value == "" {
+			continue // block list follows on subsequent lines
+		}
+		*currentField = append(*currentField, parseYAMLInlineList(value)...)
+	}
+
+	return nil
+}
+
+// parseYAMLInlineList parses a YAML flow sequence like "[a, b, c]" into its
+// elements.
+func parseYAMLInlineList(value string) []string {
+	value = strings.TrimPrefix(value, "[")
+	value = strings.TrimSuffix(value, "]")
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		item = strings.Trim(item, `"'`)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
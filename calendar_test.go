@@ -0,0 +1,65 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCalendarYAML_BlockAndInlineLists(t *testing.T) {
+	data := []byte(`
+closed_dates:
+  - 2026-01-02
+  - 2026-05-01
+open_dates: [2026-01-03, "2026-05-02"]
+closed_weekdays:
+  - Sat
+  - Sun
+`)
+
+	var raw rawCompanyCalendar
+	if err := parseCalendarYAML(data, &raw); err != nil {
+		t.Fatalf("parseCalendarYAML() error = %v", err)
+	}
+
+	want := rawCompanyCalendar{
+		ClosedDates:    []string{"2026-01-02", "2026-05-01"},
+		OpenDates:      []string{"2026-01-03", "2026-05-02"},
+		ClosedWeekdays: []string{"Sat", "Sun"},
+	}
+	if !reflect.DeepEqual(raw, want) {
+		t.Errorf("parseCalendarYAML() = %+v, want %+v", raw, want)
+	}
+}
+
+func TestParseCalendarYAML_UnknownKey(t *testing.T) {
+	var raw rawCompanyCalendar
+	err := parseCalendarYAML([]byte("bogus_key:\n  - x\n"), &raw)
+	if err == nil {
+		t.Fatal("parseCalendarYAML() with an unknown key: want error, got nil")
+	}
+}
+
+func TestParseCalendarYAML_ListItemBeforeKey(t *testing.T) {
+	var raw rawCompanyCalendar
+	err := parseCalendarYAML([]byte("- 2026-01-02\n"), &raw)
+	if err == nil {
+		t.Fatal("parseCalendarYAML() with a list item before any key: want error, got nil")
+	}
+}
+
+func TestParseYAMLInlineList(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"[a, b, c]", []string{"a", "b", "c"}},
+		{`["a", 'b']`, []string{"a", "b"}},
+		{"[]", nil},
+	}
+	for _, tt := range tests {
+		got := parseYAMLInlineList(tt.value)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseYAMLInlineList(%q) = %#v, want %#v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package holiday
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed syukujitsu.csv
+var syukujitsuCSV string
+
+var (
+	csvOnce     sync.Once
+	csvData     map[time.Time]string
+	csvLastYear int
+)
+
+// csvHolidays parses the embedded Cabinet Office CSV once and caches the
+// result, keyed by the date at midnight UTC.
+func csvHolidays() map[time.Time]string {
+	csvOnce.Do(func() {
+		csvData = make(map[time.Time]string)
+
+		r := csv.NewReader(strings.NewReader(syukujitsuCSV))
+		records, err := r.ReadAll()
+		if err != nil {
+			return
+		}
+
+		for _, rec := range records {
+			if len(rec) != 2 {
+				continue
+			}
+			date, err := parseCSVDate(rec[0])
+			if err != nil {
+				continue // header row or malformed line
+			}
+			csvData[date] = rec[1]
+			if date.Year() > csvLastYear {
+				csvLastYear = date.Year()
+			}
+		}
+	})
+	return csvData
+}
+
+// lastCSVYear returns the last year the embedded CSV has data for.
+func lastCSVYear() int {
+	csvHolidays()
+	return csvLastYear
+}
+
+// parseCSVDate parses the Cabinet Office CSV's "Y/M/D" date column.
+func parseCSVDate(s string) (time.Time, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 {
+		return time.Time{}, strconv.ErrSyntax
+	}
+	y, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return time.Time{}, err
+	}
+	m, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return time.Time{}, err
+	}
+	d, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC), nil
+}
@@ -0,0 +1,94 @@
+// Package holiday computes Japanese holidays offline.
+//
+// It combines two data sources: the Cabinet Office's syukujitsu.csv, which is
+// embedded at build time and treated as the source of truth for every date it
+// lists, and an algorithmic layer that reproduces the current Public Holidays
+// Act for dates beyond the CSV's coverage. Because the CSV is only updated
+// when the law changes, the algorithm cannot anticipate ad-hoc amendments
+// (such as the one-off Olympic date shifts in 2020/2021) for years that
+// haven't happened yet; it is a best-effort extrapolation of the recurring
+// rules only.
+package holiday
+
+import "time"
+
+// Kind distinguishes the three categories of Japanese public holiday.
+type Kind int
+
+const (
+	// KindNone means the date is not a holiday.
+	KindNone Kind = iota
+	// KindNational is 国民の祝日, a holiday named by the Public Holidays Act.
+	KindNational
+	// KindSubstitute is 振替休日, the nearest non-holiday day after a
+	// holiday that fell on a Sunday.
+	KindSubstitute
+	// KindCitizens is 国民の休日, a non-holiday day sandwiched between two
+	// national holidays.
+	KindCitizens
+)
+
+// String returns a human-readable Japanese label for the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindNational:
+		return "国民の祝日"
+	case KindSubstitute:
+		return "振替休日"
+	case KindCitizens:
+		return "国民の休日"
+	default:
+		return ""
+	}
+}
+
+// IsHoliday reports whether date is a Japanese public holiday, along with its
+// name and Kind. It consults the embedded CSV first and falls back to the
+// algorithmic rules for years after the last one the CSV covers; dates before
+// the CSV's first year are reported as not a holiday.
+func IsHoliday(date time.Time) (bool, string, Kind) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	if name, ok := csvHolidays()[day]; ok {
+		return true, name, KindFromName(name)
+	}
+
+	if day.Year() > lastCSVYear() {
+		if name, ok := algorithmicHolidays(day.Year())[day]; ok {
+			return true, name, KindFromName(name)
+		}
+	}
+
+	return false, "", KindNone
+}
+
+// HolidaysBetween returns the holiday name for every holiday in the
+// inclusive [from, to] range, keyed by midnight UTC on that date. It exists
+// so callers checking a whole span (e.g. counting business days in a year)
+// can do it in one pass instead of calling IsHoliday once per day.
+func HolidaysBetween(from, to time.Time) map[time.Time]string {
+	result := make(map[time.Time]string)
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if ok, name, _ := IsHoliday(day); ok {
+			result[day] = name
+		}
+	}
+	return result
+}
+
+// KindFromName classifies a holiday name the way the Cabinet Office CSV
+// spells it, for callers that only have a name string (e.g. a pluggable
+// HolidaySource backed by a third-party API).
+func KindFromName(name string) Kind {
+	switch name {
+	case "振替休日":
+		return KindSubstitute
+	case "国民の休日":
+		return KindCitizens
+	default:
+		return KindNational
+	}
+}
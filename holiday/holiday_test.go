@@ -0,0 +1,95 @@
+package holiday
+
+import (
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestIsHoliday_CSVAlgorithmicBoundary(t *testing.T) {
+	last := lastCSVYear()
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		wantOK   bool
+		wantName string
+		wantKind Kind
+	}{
+		{"last CSV year holiday comes from the CSV", date(last, time.November, 23), true, "勤労感謝の日", KindNational},
+		{"first post-CSV year falls back to the algorithm", date(last+1, time.January, 1), true, "元日", KindNational},
+		{"an ordinary weekday is not a holiday", date(last+1, time.January, 4), false, "", KindNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, name, kind := IsHoliday(tt.date)
+			if ok != tt.wantOK || name != tt.wantName || kind != tt.wantKind {
+				t.Errorf("IsHoliday(%s) = %v, %q, %v; want %v, %q, %v",
+					tt.date.Format("2006-01-02"), ok, name, kind, tt.wantOK, tt.wantName, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestAlgorithmicHolidays_GoldenWeekSubstituteChain(t *testing.T) {
+	// 2031: 5/4 (みどりの日) falls on a Sunday and is immediately followed by
+	// another holiday (5/5, こどもの日), so the substitute holiday must skip
+	// past it to the next open day, 5/6.
+	year := 2031
+	if date(year, time.May, 4).Weekday() != time.Sunday {
+		t.Fatalf("test fixture assumption broken: %d-05-04 is no longer a Sunday", year)
+	}
+
+	holidays := algorithmicHolidays(year)
+
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		{date(year, time.May, 3), "憲法記念日"},
+		{date(year, time.May, 4), "みどりの日"},
+		{date(year, time.May, 5), "こどもの日"},
+		{date(year, time.May, 6), "振替休日"},
+	}
+	for _, tt := range tests {
+		got := holidays[tt.date]
+		if got != tt.want {
+			t.Errorf("holidays[%s] = %q, want %q", tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestDeriveSubstitutesAndCitizens_CitizensHoliday(t *testing.T) {
+	// Silver Week: when 敬老の日 and 秋分の日 are two days apart with an
+	// otherwise-open Sunday-free day between them, that day becomes 国民の休日.
+	national := map[time.Time]string{
+		date(2009, time.September, 21): "敬老の日",
+		date(2009, time.September, 23): "秋分の日",
+	}
+
+	all := deriveSubstitutesAndCitizens(2009, national)
+
+	if got := all[date(2009, time.September, 22)]; got != "国民の休日" {
+		t.Errorf("all[2009-09-22] = %q, want 国民の休日", got)
+	}
+}
+
+func TestKindFromName(t *testing.T) {
+	tests := []struct {
+		name string
+		want Kind
+	}{
+		{"振替休日", KindSubstitute},
+		{"国民の休日", KindCitizens},
+		{"元日", KindNational},
+	}
+	for _, tt := range tests {
+		if got := KindFromName(tt.name); got != tt.want {
+			t.Errorf("KindFromName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package holiday
+
+import (
+	"math"
+	"time"
+)
+
+// algorithmicHolidays computes the recurring national holidays for year,
+// plus the substitute (振替休日) and citizens' (国民の休日) holidays that
+// follow from them, per the current Public Holidays Act. It is only used for
+// years beyond the embedded CSV's coverage, so it does not account for
+// one-off amendments that haven't been legislated yet.
+func algorithmicHolidays(year int) map[time.Time]string {
+	national := nationalHolidays(year)
+	return deriveSubstitutesAndCitizens(year, national)
+}
+
+// nationalHolidays returns the fixed-date, Happy-Monday and equinox holidays
+// for year as map keyed by date.
+func nationalHolidays(year int) map[time.Time]string {
+	hs := make(map[time.Time]string)
+	d := func(m time.Month, day int) time.Time { return time.Date(year, m, day, 0, 0, 0, 0, time.UTC) }
+
+	hs[d(time.January, 1)] = "元日"
+	hs[nthMonday(year, time.January, 2)] = "成人の日"
+	hs[d(time.February, 11)] = "建国記念の日"
+	hs[d(time.February, 23)] = "天皇誕生日"
+	hs[d(time.March, vernalEquinoxDay(year))] = "春分の日"
+	hs[d(time.April, 29)] = "昭和の日"
+	hs[d(time.May, 3)] = "憲法記念日"
+	hs[d(time.May, 4)] = "みどりの日"
+	hs[d(time.May, 5)] = "こどもの日"
+	hs[nthMonday(year, time.July, 3)] = "海の日"
+	hs[d(time.August, 11)] = "山の日"
+	hs[nthMonday(year, time.September, 3)] = "敬老の日"
+	hs[d(time.September, autumnalEquinoxDay(year))] = "秋分の日"
+	hs[nthMonday(year, time.October, 2)] = "スポーツの日"
+	hs[d(time.November, 3)] = "文化の日"
+	hs[d(time.November, 23)] = "勤労感謝の日"
+
+	return hs
+}
+
+// deriveSubstitutesAndCitizens adds 振替休日 and 国民の休日 on top of the
+// national holidays already in national.
+func deriveSubstitutesAndCitizens(year int, national map[time.Time]string) map[time.Time]string {
+	all := make(map[time.Time]string, len(national))
+	for date, name := range national {
+		all[date] = name
+	}
+
+	for date := range national {
+		if date.Weekday() != time.Sunday {
+			continue
+		}
+		next := date.AddDate(0, 0, 1)
+		for {
+			if _, ok := national[next]; !ok {
+				all[next] = "振替休日"
+				break
+			}
+			next = next.AddDate(0, 0, 1)
+		}
+	}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if _, ok := all[day]; ok || day.Weekday() == time.Sunday {
+			continue
+		}
+		_, prevOK := national[day.AddDate(0, 0, -1)]
+		_, nextOK := national[day.AddDate(0, 0, 1)]
+		if prevOK && nextOK {
+			all[day] = "国民の休日"
+		}
+	}
+
+	return all
+}
+
+// nthMonday returns the date of the nth Monday of month in year, the
+// standard "Happy Monday" rule used for 成人の日, 海の日, 敬老の日 and
+// スポーツの日.
+func nthMonday(year int, month time.Month, n int) time.Time {
+	date := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	count := 0
+	for {
+		if date.Weekday() == time.Monday {
+			count++
+			if count == n {
+				return date
+			}
+		}
+		date = date.AddDate(0, 0, 1)
+	}
+}
+
+// vernalEquinoxDay approximates the March day of the spring equinox (春分の
+// 日) using the standard formula, valid for 1980-2099.
+func vernalEquinoxDay(year int) int {
+	return int(math.Floor(20.8431 + 0.242194*float64(year-1980) - math.Floor(float64(year-1980)/4)))
+}
+
+// autumnalEquinoxDay approximates the September day of the autumn equinox
+// (秋分の日) using the standard formula, valid for 1980-2099.
+func autumnalEquinoxDay(year int) int {
+	return int(math.Floor(23.2488 + 0.242194*float64(year-1980) - math.Floor(float64(year-1980)/4)))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -28,21 +29,84 @@ var weekdayJP = map[time.Weekday]string{
 
 func main() {
 	var (
-		dateStr string
-		verbose bool
-		help    bool
+		dateStr      string
+		verbose      bool
+		help         bool
+		strict       bool
+		cacheDir     string
+		cacheTTL     time.Duration
+		source       string
+		csvPath      string
+		googleAPIKey string
+		n            int
+		direction    string
+		countBetween string
+		calendarPath string
+		serveAddr    string
 	)
 
 	flag.StringVar(&dateStr, "date", "", "基準日 (YYYY-MM-DD形式、デフォルト: 今日)")
 	flag.BoolVar(&verbose, "verbose", false, "詳細表示モード")
 	flag.BoolVar(&help, "help", false, "ヘルプを表示")
+	flag.BoolVar(&strict, "strict", false, "祝日判定をjp-holiday.net APIでも検証する")
+	flag.StringVar(&cacheDir, "cache-dir", defaultCacheDir(), "APIレスポンスのキャッシュディレクトリ")
+	flag.DurationVar(&cacheTTL, "cache-ttl", 7*24*time.Hour, "当日以降の日付のキャッシュ有効期間")
+	flag.StringVar(&source, "source", "csv", "祝日データソース (jpholidaynet, gcal, csv)。jpholidaynetには範囲APIがないため、-nや-count-betweenでは1日ずつ問い合わせます")
+	flag.StringVar(&csvPath, "csv", "", "内閣府syukujitsu.csvのパス (未指定時は内蔵データを使用)")
+	flag.StringVar(&googleAPIKey, "google-api-key", "", "Google Calendar APIキー (未指定時はGOOGLE_API_KEY環境変数を使用)")
+	flag.IntVar(&n, "n", 1, "基準日からN番目の営業日")
+	flag.StringVar(&direction, "direction", "prev", "探索方向 (prev, next)")
+	flag.StringVar(&countBetween, "count-between", "", "範囲内の営業日数を数える (FROM..TO、YYYY-MM-DD形式)")
+	flag.StringVar(&calendarPath, "calendar", "", "会社カレンダーファイル (YAML/JSON) で祝日判定を上書きする")
+	flag.StringVar(&serveAddr, "serve", "", "HTTP/JSONサービスとして起動する (例: :8080)")
 	flag.Parse()
 
+	if googleAPIKey == "" {
+		googleAPIKey = os.Getenv("GOOGLE_API_KEY")
+	}
+
 	if help {
 		printHelp()
 		os.Exit(0)
 	}
 
+	if !isValidSource(source) {
+		fmt.Fprintf(os.Stderr, "エラー: 無効なsourceです: %s\n", source)
+		os.Exit(1)
+	}
+
+	var calendar *companyCalendar
+	if calendarPath != "" {
+		var err error
+		calendar, err = loadCompanyCalendar(calendarPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "エラー: calendarファイルの読み込みに失敗しました: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	cache := newHolidayCache(cacheDir, cacheTTL)
+	opts := bizDayOptions{
+		sources:  buildSources(source, cache, csvPath, googleAPIKey),
+		strict:   strict,
+		verify:   &jpHolidayNetSource{cache: cache},
+		calendar: calendar,
+	}
+
+	if serveAddr != "" {
+		fmt.Printf("jp-prev-bizday serverを起動しました: %s\n", serveAddr)
+		if err := runServer(serveAddr, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "エラー: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if countBetween != "" {
+		runCountBetween(countBetween, opts)
+		return
+	}
+
 	// Set base date
 	var baseDate time.Time
 	var err error
@@ -58,8 +122,7 @@ func main() {
 		}
 	}
 
-	// Find the previous business day
-	prevBizDay, err := findPreviousBusinessDay(baseDate)
+	bizDay, err := BusinessDays(baseDate, n, direction, opts)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "エラー: %v\n", err)
 		os.Exit(1)
@@ -67,10 +130,37 @@ func main() {
 
 	// Output results
 	if verbose {
-		outputVerbose(baseDate, prevBizDay)
+		outputVerbose(baseDate, bizDay, direction)
 	} else {
-		outputSimple(prevBizDay)
+		outputSimple(bizDay)
+	}
+}
+
+// runCountBetween handles the -count-between FROM..TO mode.
+func runCountBetween(spec string, opts bizDayOptions) {
+	fromStr, toStr, ok := strings.Cut(spec, "..")
+	if !ok {
+		fmt.Fprintf(os.Stderr, "エラー: count-betweenはFROM..TO形式で指定してください: %s\n", spec)
+		os.Exit(1)
+	}
+
+	from, err := time.Parse("2006-01-02", fromStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "エラー: 無効な日付形式です: %s\n", fromStr)
+		os.Exit(1)
+	}
+	to, err := time.Parse("2006-01-02", toStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "エラー: 無効な日付形式です: %s\n", toStr)
+		os.Exit(1)
 	}
+
+	count, err := CountBetween(from, to, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "エラー: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(count)
 }
 
 // printHelp displays the help message
@@ -88,6 +178,18 @@ func printHelp() {
 	fmt.Println("オプション:")
 	fmt.Println("  -date string    基準日 (YYYY-MM-DD形式、デフォルト: 今日)")
 	fmt.Println("  -verbose        詳細表示モード")
+	fmt.Println("  -strict         祝日判定をjp-holiday.net APIでも検証する")
+	fmt.Println("  -cache-dir string  APIレスポンスのキャッシュディレクトリ (デフォルト: " + defaultCacheDir() + ")")
+	fmt.Println("  -cache-ttl duration  当日以降の日付のキャッシュ有効期間 (デフォルト: 168h0m0s)")
+	fmt.Println("  -source string  祝日データソース: jpholidaynet, gcal, csv (デフォルト: csv)")
+	fmt.Println("                  jpholidaynetは範囲APIがないため-n/-count-betweenでは1日ずつ問い合わせます")
+	fmt.Println("  -csv string     内閣府syukujitsu.csvのパス (未指定時は内蔵データを使用)")
+	fmt.Println("  -google-api-key string  Google Calendar APIキー (未指定時はGOOGLE_API_KEY環境変数を使用)")
+	fmt.Println("  -n int          基準日からN番目の営業日 (デフォルト: 1)")
+	fmt.Println("  -direction string  探索方向: prev, next (デフォルト: prev)")
+	fmt.Println("  -count-between FROM..TO  範囲内の営業日数を数える (YYYY-MM-DD形式)")
+	fmt.Println("  -calendar string  会社カレンダーファイル (YAML/JSON) で祝日判定を上書きする")
+	fmt.Println("  -serve string   HTTP/JSONサービスとして起動する (例: :8080)")
 	fmt.Println("  -help           このヘルプを表示")
 	fmt.Println()
 	fmt.Println("例:")
@@ -96,52 +198,21 @@ func printHelp() {
 	fmt.Println("  jp-prev-bizday -verbose")
 }
 
-// findPreviousBusinessDay returns the first business day before the specified date
-func findPreviousBusinessDay(from time.Time) (time.Time, error) {
-	// Search up to 30 days back (sufficient for practical use)
-	maxDays := 30
-	current := from.AddDate(0, 0, -1) // Start from one day before
-
-	for i := 0; i < maxDays; i++ {
-		isBizDay, err := isBusinessDay(current)
-		if err != nil {
-			return time.Time{}, fmt.Errorf("営業日判定エラー: %w", err)
-		}
-
-		if isBizDay {
-			return current, nil
-		}
-
-		current = current.AddDate(0, 0, -1)
-	}
-
-	return time.Time{}, fmt.Errorf("営業日が見つかりませんでした")
-}
-
-// isBusinessDay checks if the specified date is a business day
-func isBusinessDay(date time.Time) (bool, error) {
-	// Check if it's a weekend
-	if isWeekend(date) {
-		return false, nil
-	}
-
-	// Check if it's a holiday
-	isHoliday, _, err := checkHoliday(date)
-	if err != nil {
-		return false, err
-	}
-
-	return !isHoliday, nil
-}
-
 // isWeekend checks if the date is Saturday or Sunday
 func isWeekend(date time.Time) bool {
 	weekday := date.Weekday()
 	return weekday == time.Saturday || weekday == time.Sunday
 }
 
-// checkHoliday calls the Japanese holiday API to check if the date is a holiday
-func checkHoliday(date time.Time) (bool, string, error) {
+// checkHoliday calls the Japanese holiday API to check if the date is a
+// holiday, consulting cache first and persisting successful responses to it.
+func checkHoliday(date time.Time, cache *holidayCache) (bool, string, error) {
+	if cache != nil {
+		if entry, ok := cache.get(date); ok {
+			return entry.Holiday, entry.Name, nil
+		}
+	}
+
 	// Build API endpoint URL
 	url := fmt.Sprintf("https://jp-holiday.net/api/v1/holiday/%d/%02d/%02d",
 		date.Year(), date.Month(), date.Day())
@@ -169,6 +240,12 @@ func checkHoliday(date time.Time) (bool, string, error) {
 		return false, "", fmt.Errorf("JSONパースエラー: %w", err)
 	}
 
+	if cache != nil {
+		if err := cache.set(date, holiday.Holiday, holiday.Name); err != nil {
+			fmt.Fprintf(os.Stderr, "警告: キャッシュの保存に失敗しました: %v\n", err)
+		}
+	}
+
 	return holiday.Holiday, holiday.Name, nil
 }
 
@@ -178,11 +255,16 @@ func outputSimple(date time.Time) {
 }
 
 // outputVerbose outputs detailed information
-func outputVerbose(baseDate, businessDay time.Time) {
+func outputVerbose(baseDate, businessDay time.Time, direction string) {
+	label := "直前の営業日"
+	if direction == "next" {
+		label = "直後の営業日"
+	}
 	fmt.Printf("基準日: %s (%s)\n",
 		baseDate.Format("2006-01-02"),
 		weekdayJP[baseDate.Weekday()])
-	fmt.Printf("直前の営業日: %s (%s)\n",
+	fmt.Printf("%s: %s (%s)\n",
+		label,
 		businessDay.Format("2006-01-02"),
 		weekdayJP[businessDay.Weekday()])
 }
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/zinrai/jp-prev-bizday/holiday"
+)
+
+// holidayRecord is the JSON shape of each entry returned by /holidays.
+// type follows the 0/1/2/3 scheme:
+// Sunday / 国民の祝日 / 振替休日 / 国民の休日.
+type holidayRecord struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+	Type int    `json:"type"`
+}
+
+// runServer starts the -serve HTTP/JSON service on addr (":PORT").
+func runServer(addr string, opts bizDayOptions) error {
+	group := newCallGroup()
+	check := func(date time.Time) (bool, string, error) {
+		return group.do(dateKey(date), func() (bool, string, error) {
+			return checkHolidayWithFailover(date, opts.sources)
+		})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/prev", handleBusinessDay(opts, "prev"))
+	mux.HandleFunc("/next", handleBusinessDay(opts, "next"))
+	mux.HandleFunc("/is-business-day", handleIsBusinessDay(opts, check))
+	mux.HandleFunc("/holidays", handleHolidays(opts, check))
+
+	return http.ListenAndServe(addr, mux)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func parseQueryDate(r *http.Request, param string) (time.Time, error) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("%sは必須です", param)
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// handleBusinessDay serves /prev and /next: GET ?date=YYYY-MM-DD[&n=1]
+//
+// Unlike handleIsBusinessDay and handleHolidays, this handler doesn't go
+// through the callGroup-coalesced check closure: BusinessDays scans a whole
+// window via batchCheckHolidays, which honors opts.sources (and thus -source)
+// in the same failover order on its own.
+func handleBusinessDay(opts bizDayOptions, direction string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		date, err := parseQueryDate(r, "date")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		n := 1
+		if nStr := r.URL.Query().Get("n"); nStr != "" {
+			if _, err := fmt.Sscanf(nStr, "%d", &n); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("nは整数で指定してください"))
+				return
+			}
+		}
+
+		result, err := BusinessDays(date, n, direction, opts)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]string{"date": result.Format("2006-01-02")})
+	}
+}
+
+// handleIsBusinessDay serves /is-business-day: GET ?date=YYYY-MM-DD
+func handleIsBusinessDay(opts bizDayOptions, check func(time.Time) (bool, string, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		date, err := parseQueryDate(r, "date")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		isHoliday, _, err := check(date)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		isBizDay := isBusinessDayAt(date, map[string]bool{dateKey(date): isHoliday}, opts)
+		writeJSON(w, http.StatusOK, map[string]any{
+			"date":            date.Format("2006-01-02"),
+			"is_business_day": isBizDay,
+		})
+	}
+}
+
+// handleHolidays serves /holidays: GET ?from=YYYY-MM-DD&to=YYYY-MM-DD
+func handleHolidays(opts bizDayOptions, check func(time.Time) (bool, string, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, err := parseQueryDate(r, "from")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		to, err := parseQueryDate(r, "to")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if to.Before(from) {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("toはfrom以降を指定してください"))
+			return
+		}
+
+		var records []holidayRecord
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			isHoliday, name, err := check(d)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			switch {
+			case isHoliday:
+				records = append(records, holidayRecord{
+					Date: d.Format("2006-01-02"),
+					Name: name,
+					Type: int(holiday.KindFromName(name)),
+				})
+			case d.Weekday() == time.Sunday:
+				records = append(records, holidayRecord{Date: d.Format("2006-01-02"), Type: 0})
+			}
+		}
+
+		writeJSON(w, http.StatusOK, records)
+	}
+}
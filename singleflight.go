@@ -0,0 +1,47 @@
+package main
+
+import "sync"
+
+// callGroup deduplicates concurrent holiday lookups for the same key, so
+// that N simultaneous HTTP requests for the same date only trigger one
+// underlying source check.
+type callGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inFlightCall
+}
+
+type inFlightCall struct {
+	wg        sync.WaitGroup
+	isHoliday bool
+	name      string
+	err       error
+}
+
+func newCallGroup() *callGroup {
+	return &callGroup{calls: make(map[string]*inFlightCall)}
+}
+
+// do runs fn for key, or waits for an identical call already in flight and
+// returns its result.
+func (g *callGroup) do(key string, fn func() (bool, string, error)) (bool, string, error) {
+	g.mu.Lock()
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.isHoliday, c.name, c.err
+	}
+
+	c := &inFlightCall{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.isHoliday, c.name, c.err = fn()
+	c.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return c.isHoliday, c.name, c.err
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zinrai/jp-prev-bizday/holiday"
+)
+
+// HolidaySource looks up whether a date is a Japanese holiday.
+type HolidaySource interface {
+	IsHoliday(date time.Time) (bool, string, error)
+}
+
+// sourceOrder is the fixed failover order: whichever source -source selects
+// is tried first, then the rest are tried in this order. Unless it is the
+// selected source, csv comes last because it's offline and, barring a corrupt
+// -csv file, never errors.
+var sourceOrder = []string{"jpholidaynet", "gcal", "csv"}
+
+// buildSources returns the failover chain for primary, skipping gcal if no
+// API key was configured.
+func buildSources(primary string, cache *holidayCache, csvPath, googleAPIKey string) []HolidaySource {
+	order := append([]string{primary}, sourceOrder...)
+
+	seen := make(map[string]bool)
+	var sources []HolidaySource
+	for _, name := range order {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		switch name {
+		case "jpholidaynet":
+			sources = append(sources, &jpHolidayNetSource{cache: cache})
+		case "gcal":
+			if googleAPIKey != "" {
+				sources = append(sources, &googleCalendarSource{apiKey: googleAPIKey})
+			}
+		case "csv":
+			sources = append(sources, &csvSource{path: csvPath})
+		}
+	}
+	return sources
+}
+
+// checkHolidayWithFailover tries each source in order, returning the first
+// successful result. If every source errors, the last error is returned.
+func checkHolidayWithFailover(date time.Time, sources []HolidaySource) (bool, string, error) {
+	var lastErr error
+	for _, src := range sources {
+		isHoliday, name, err := src.IsHoliday(date)
+		if err == nil {
+			return isHoliday, name, nil
+		}
+		lastErr = err
+	}
+	return false, "", lastErr
+}
+
+// BatchHolidaySource is implemented by sources that can answer a whole date
+// span in one round trip. batchCheckHolidays uses it to avoid one call per
+// day when scanning a range.
+type BatchHolidaySource interface {
+	HolidaysBetween(from, to time.Time) (map[string]string, error)
+}
+
+// batchCheckHolidays resolves every date in the inclusive [from, to] range to
+// whether it's a holiday, trying sources in order exactly like
+// checkHolidayWithFailover does: the first source is used for the whole
+// range unless it errors, in which case the next one is tried. A source only
+// skips straight to per-day IsHoliday calls (instead of a single
+// HolidaysBetween round trip) when it doesn't implement BatchHolidaySource;
+// it is never skipped just because a later source happens to support batching.
+func batchCheckHolidays(from, to time.Time, sources []HolidaySource) (map[string]bool, error) {
+	var lastErr error
+	for _, src := range sources {
+		names, err := holidaysBetweenForSource(src, from, to)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		result := make(map[string]bool)
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			_, isHoliday := names[dateKey(d)]
+			result[dateKey(d)] = isHoliday
+		}
+		return result, nil
+	}
+	return nil, lastErr
+}
+
+// holidaysBetweenForSource resolves [from, to] from a single source: via its
+// own HolidaysBetween when it implements BatchHolidaySource, or by calling
+// IsHoliday once per day otherwise.
+func holidaysBetweenForSource(src HolidaySource, from, to time.Time) (map[string]string, error) {
+	if b, ok := src.(BatchHolidaySource); ok {
+		return b.HolidaysBetween(from, to)
+	}
+
+	names := make(map[string]string)
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		isHoliday, name, err := src.IsHoliday(d)
+		if err != nil {
+			return nil, err
+		}
+		if isHoliday {
+			names[dateKey(d)] = name
+		}
+	}
+	return names, nil
+}
+
+// jpHolidayNetSource queries the jp-holiday.net API, consulting cache first.
+//
+// It deliberately doesn't implement BatchHolidaySource: jp-holiday.net has no
+// range endpoint, so -source jpholidaynet falls back to one cache-backed
+// IsHoliday call per day in holidaysBetweenForSource for -n/-count-between
+// scans, unlike the default csv source's single-pass lookup.
+type jpHolidayNetSource struct {
+	cache *holidayCache
+}
+
+func (s *jpHolidayNetSource) IsHoliday(date time.Time) (bool, string, error) {
+	return checkHoliday(date, s.cache)
+}
+
+// csvSource answers from the Cabinet Office CSV: the embedded one via the
+// holiday package, or an external file when path is set.
+type csvSource struct {
+	path string
+}
+
+func (s *csvSource) IsHoliday(date time.Time) (bool, string, error) {
+	if s.path == "" {
+		isHoliday, name, _ := holiday.IsHoliday(date)
+		return isHoliday, name, nil
+	}
+
+	entries, err := loadCSVFile(s.path)
+	if err != nil {
+		return false, "", fmt.Errorf("CSV読み込みエラー: %w", err)
+	}
+
+	name, ok := entries[date.Format("2006-01-02")]
+	return ok, name, nil
+}
+
+// HolidaysBetween answers a whole range from the CSV in one pass.
+func (s *csvSource) HolidaysBetween(from, to time.Time) (map[string]string, error) {
+	if s.path == "" {
+		result := make(map[string]string)
+		for day, name := range holiday.HolidaysBetween(from, to) {
+			result[dateKey(day)] = name
+		}
+		return result, nil
+	}
+
+	entries, err := loadCSVFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("CSV読み込みエラー: %w", err)
+	}
+
+	result := make(map[string]string)
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if name, ok := entries[dateKey(d)]; ok {
+			result[dateKey(d)] = name
+		}
+	}
+	return result, nil
+}
+
+// loadCSVFile parses a Cabinet Office-format CSV ("Y/M/D,name" rows) from an
+// external path.
+func loadCSVFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := csv.NewReader(strings.NewReader(string(data)))
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]string)
+	for _, rec := range records {
+		if len(rec) != 2 {
+			continue
+		}
+		key, err := normalizeCSVDate(rec[0])
+		if err != nil {
+			continue // header row or malformed line
+		}
+		entries[key] = rec[1]
+	}
+	return entries, nil
+}
+
+// normalizeCSVDate converts the Cabinet Office CSV's "Y/M/D" column into the
+// "YYYY-MM-DD" key used elsewhere in this package.
+func normalizeCSVDate(s string) (string, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 {
+		return "", strconv.ErrSyntax
+	}
+	y, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return "", err
+	}
+	m, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", err
+	}
+	d, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", y, m, d), nil
+}
+
+// googleCalendarSource queries Google's public Japanese holiday calendar.
+type googleCalendarSource struct {
+	apiKey string
+}
+
+// googleCalendarID is Google's public Japanese holiday calendar.
+const googleCalendarID = "ja.japanese#holiday@group.v.calendar.google.com"
+
+type gcalEventsResponse struct {
+	Items []struct {
+		Summary string `json:"summary"`
+		Start   struct {
+			Date string `json:"date"`
+		} `json:"start"`
+	} `json:"items"`
+}
+
+// fetchEvents lists every event on the holiday calendar in [timeMin, timeMax).
+func (s *googleCalendarSource) fetchEvents(timeMin, timeMax time.Time) (gcalEventsResponse, error) {
+	var events gcalEventsResponse
+	if s.apiKey == "" {
+		return events, fmt.Errorf("Google Calendar APIキーが設定されていません")
+	}
+
+	endpoint := fmt.Sprintf(
+		"https://www.googleapis.com/calendar/v3/calendars/%s/events?%s",
+		url.PathEscape(googleCalendarID),
+		url.Values{
+			"key":          {s.apiKey},
+			"timeMin":      {timeMin.Format("2006-01-02") + "T00:00:00Z"},
+			"timeMax":      {timeMax.Format("2006-01-02") + "T00:00:00Z"},
+			"singleEvents": {"true"},
+		}.Encode(),
+	)
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(endpoint)
+	if err != nil {
+		return events, fmt.Errorf("Google Calendar API呼び出しエラー: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return events, fmt.Errorf("Google Calendar APIエラー: ステータスコード %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return events, fmt.Errorf("JSONパースエラー: %w", err)
+	}
+	return events, nil
+}
+
+func (s *googleCalendarSource) IsHoliday(date time.Time) (bool, string, error) {
+	events, err := s.fetchEvents(date, date.AddDate(0, 0, 1))
+	if err != nil {
+		return false, "", err
+	}
+	if len(events.Items) == 0 {
+		return false, "", nil
+	}
+	return true, events.Items[0].Summary, nil
+}
+
+// HolidaysBetween fetches the whole [from, to] range in a single API call.
+func (s *googleCalendarSource) HolidaysBetween(from, to time.Time) (map[string]string, error) {
+	events, err := s.fetchEvents(from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+	for _, item := range events.Items {
+		result[item.Start.Date] = item.Summary
+	}
+	return result, nil
+}